@@ -1,6 +1,7 @@
 package mpv
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -179,6 +180,115 @@ func (c *Client) Seek(n int, mode string) error {
 	return err
 }
 
+// SeekPrecise seeks to seconds using mode (SeekModeRelative or
+// SeekModeAbsolute) combined with mpv's exact flag, landing on the exact
+// frame instead of the nearest keyframe.
+func (c *Client) SeekPrecise(seconds float64, mode string) error {
+	_, err := c.Exec("seek", strconv.FormatFloat(seconds, 'f', -1, 64), mode+"+exact")
+	return err
+}
+
+// SetABLoop sets the A-B loop points, in seconds. Playback loops between
+// a and b once both are set.
+func (c *Client) SetABLoop(a, b float64) error {
+	if err := c.SetProperty("ab-loop-a", a); err != nil {
+		return err
+	}
+	return c.SetProperty("ab-loop-b", b)
+}
+
+// ClearABLoop disables the A-B loop set via SetABLoop.
+func (c *Client) ClearABLoop() error {
+	if err := c.SetProperty("ab-loop-a", "no"); err != nil {
+		return err
+	}
+	return c.SetProperty("ab-loop-b", "no")
+}
+
+// Chapter is a single entry of mpv's chapter-list property.
+type Chapter struct {
+	Title string  `json:"title"`
+	Time  float64 `json:"time"`
+}
+
+// Chapters returns the chapter list of the currently playing file.
+func (c *Client) Chapters() ([]Chapter, error) {
+	res, err := c.Exec("get_property", "chapter-list")
+	if res == nil {
+		return nil, err
+	}
+	var chapters []Chapter
+	if err := json.Unmarshal(res.Data, &chapters); err != nil {
+		return nil, fmt.Errorf("data %s, err %v", res.Data, err)
+	}
+	return chapters, nil
+}
+
+// SetChapter jumps to chapter index i.
+func (c *Client) SetChapter(i int) error {
+	return c.SetProperty("chapter", i)
+}
+
+// NextChapter jumps to the next chapter, or NOP if there isn't one.
+func (c *Client) NextChapter() error {
+	_, err := c.Exec("add", "chapter", 1)
+	return err
+}
+
+// PrevChapter jumps to the previous chapter, or NOP if there isn't one.
+func (c *Client) PrevChapter() error {
+	_, err := c.Exec("add", "chapter", -1)
+	return err
+}
+
+// WaitForFileLoaded blocks until mpv reports a file-loaded event, or ctx
+// is done. Use it after Loadfile to know it's safe to read properties
+// like Duration that are only populated once the file has loaded.
+func (c *Client) WaitForFileLoaded(ctx context.Context) error {
+	ch, unsub, err := c.OnFileLoaded()
+	if err != nil {
+		return err
+	}
+	defer unsub()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WaitForProperty blocks until property name's value satisfies predicate,
+// or ctx is done. The current value is checked first, in case it already
+// satisfies predicate.
+func (c *Client) WaitForProperty(ctx context.Context, name string, predicate func(json.RawMessage) bool) error {
+	if raw, err := c.GetPropertyRaw(name); err == nil && predicate(raw) {
+		return nil
+	}
+	ch, unsub, err := c.ObserveProperty(name)
+	if err != nil {
+		return err
+	}
+	defer unsub()
+	// The property may have reached a predicate-satisfying value in the
+	// gap between the check above and the subscription just established;
+	// that transition would otherwise never be observed. Re-check now
+	// that we're subscribed, before waiting on events.
+	if raw, err := c.GetPropertyRaw(name); err == nil && predicate(raw) {
+		return nil
+	}
+	for {
+		select {
+		case pc := <-ch:
+			if predicate(pc.Data) {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // PlaylistNext plays the next playlistitem or NOP if no item is available.
 func (c *Client) PlaylistNext() error {
 	_, err := c.Exec("playlist-next", "weak")
@@ -205,12 +315,25 @@ func (c *Client) LoadList(path string, mode string) error {
 }
 
 // GetProperty reads a property by name and returns the data as a string.
+// Deprecated: prefer GetPropertyAs, or a typed Property from the props
+// package (e.g. props.Pause.Get(c)), which decode into a real Go type
+// instead of leaving the caller to parse a string.
 func (c *Client) GetProperty(name string) (string, error) {
 	res, err := c.Exec("get_property", name)
 	if res == nil {
 		return "", err
 	}
-	return fmt.Sprintf("%#v", res.Data), err
+	return string(res.Data), err
+}
+
+// GetPropertyRaw reads a property by name and returns its raw JSON value,
+// e.g. for properties whose shape doesn't have a dedicated getter yet.
+func (c *Client) GetPropertyRaw(name string) (json.RawMessage, error) {
+	res, err := c.Exec("get_property", name)
+	if res == nil {
+		return nil, err
+	}
+	return res.Data, nil
 }
 
 // SetProperty sets the value of a property.
@@ -251,12 +374,12 @@ func (c *Client) GetBoolProperty(name string) (bool, error) {
 
 // Filename returns the currently playing filename
 func (c *Client) Filename() (string, error) {
-	return c.GetProperty("filename")
+	return GetPropertyAs[string](c, "filename")
 }
 
 // Path returns the currently playing path
 func (c *Client) Path() (string, error) {
-	return c.GetProperty("path")
+	return GetPropertyAs[string](c, "path")
 }
 
 // Pause returns true if the player is paused
@@ -371,3 +494,83 @@ func (c *Client) Quit(code int) error {
 	}
 	return err
 }
+
+// ErrEventsUnsupported is returned by the event subscription methods when
+// the underlying LLClient doesn't implement EventClient.
+var ErrEventsUnsupported = errors.New("LLClient does not support events")
+
+// ObserveProperty subscribes to changes of the given mpv property. The
+// returned channel receives a PropertyChange on every update; call unsub
+// once done to stop observing. Multiple subscribers observing the same
+// property share a single underlying mpv observation.
+func (c *Client) ObserveProperty(name string) (<-chan PropertyChange, func(), error) {
+	ec, ok := c.LLClient.(EventClient)
+	if !ok {
+		return nil, nil, ErrEventsUnsupported
+	}
+	return ec.ObserveProperty(name)
+}
+
+// Events subscribes to mpv events. With no filter every event is
+// delivered; otherwise only events whose name is listed are. Call unsub
+// once done to stop the subscription.
+func (c *Client) Events(filter ...string) (<-chan Event, func(), error) {
+	ec, ok := c.LLClient.(EventClient)
+	if !ok {
+		return nil, nil, ErrEventsUnsupported
+	}
+	ch, unsub := ec.Events(filter...)
+	return ch, unsub, nil
+}
+
+// OnEndFile subscribes to end-file events, fired whenever playback of the
+// current file stops (end of file, error or explicit stop).
+func (c *Client) OnEndFile() (<-chan Event, func(), error) {
+	return c.Events("end-file")
+}
+
+// OnFileLoaded subscribes to file-loaded events, fired once a file has
+// loaded enough for its properties (duration, tracks, ...) to be read.
+func (c *Client) OnFileLoaded() (<-chan Event, func(), error) {
+	return c.Events("file-loaded")
+}
+
+// OnSeek subscribes to seek events, fired whenever a seek starts.
+func (c *Client) OnSeek() (<-chan Event, func(), error) {
+	return c.Events("seek")
+}
+
+// ErrBatchUnsupported is returned by ExecContext/ExecBatch/
+// ExecBatchContext when the underlying LLClient doesn't implement
+// BatchClient.
+var ErrBatchUnsupported = errors.New("LLClient does not support batched/context execution")
+
+// ExecContext behaves like Exec but uses ctx's deadline/cancellation
+// instead of the LLClient's fixed timeout.
+func (c *Client) ExecContext(ctx context.Context, command ...interface{}) (*Response, error) {
+	bc, ok := c.LLClient.(BatchClient)
+	if !ok {
+		return nil, ErrBatchUnsupported
+	}
+	return bc.ExecContext(ctx, command...)
+}
+
+// ExecBatch issues multiple commands, pipelining the sends so callers
+// don't pay one round trip per command.
+func (c *Client) ExecBatch(commands ...[]interface{}) ([]*Response, error) {
+	bc, ok := c.LLClient.(BatchClient)
+	if !ok {
+		return nil, ErrBatchUnsupported
+	}
+	return bc.ExecBatch(commands...)
+}
+
+// ExecBatchContext behaves like ExecBatch but uses ctx's
+// deadline/cancellation instead of the LLClient's fixed timeout.
+func (c *Client) ExecBatchContext(ctx context.Context, commands ...[]interface{}) ([]*Response, error) {
+	bc, ok := c.LLClient.(BatchClient)
+	if !ok {
+		return nil, ErrBatchUnsupported
+	}
+	return bc.ExecBatchContext(ctx, commands...)
+}
@@ -0,0 +1,35 @@
+//go:build !windows
+
+package mpv
+
+import (
+	"context"
+	"net"
+	"os"
+	"time"
+)
+
+func init() {
+	dial = dialUnixSocket
+}
+
+// dialUnixSocket connects to the Unix domain socket mpv listens on when
+// started with --input-ipc-server=<path>.
+func dialUnixSocket(cx context.Context, addr string, timeout time.Duration) (net.Conn, error) {
+	d := &net.Dialer{Timeout: timeout}
+	return d.DialContext(cx, "unix", addr)
+}
+
+// defaultSocketPath generates a unique Unix domain socket path for an
+// embedded mpv Server. mpv creates the socket file itself, so the empty
+// temp file is removed again before returning.
+func defaultSocketPath() (string, error) {
+	f, err := os.CreateTemp("", "mpv-ipc-*.sock")
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	f.Close()
+	os.Remove(path)
+	return path, nil
+}
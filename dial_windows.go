@@ -0,0 +1,59 @@
+//go:build windows
+
+package mpv
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"syscall"
+	"time"
+)
+
+func init() {
+	dial = dialNamedPipe
+}
+
+// errPipeBusy is ERROR_PIPE_BUSY, not exported by package syscall.
+const errPipeBusy = syscall.Errno(231)
+
+// dialNamedPipe connects to the Windows named pipe mpv listens on when
+// started with --input-ipc-server=<path> (e.g. \\.\pipe\mpv-ipc-1). The
+// pipe may not exist yet if mpv is still starting, so ERROR_PIPE_BUSY and
+// ERROR_FILE_NOT_FOUND are retried until timeout or cx is done.
+func dialNamedPipe(cx context.Context, addr string, timeout time.Duration) (net.Conn, error) {
+	path, err := syscall.UTF16PtrFromString(addr)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		h, err := syscall.CreateFile(path,
+			syscall.GENERIC_READ|syscall.GENERIC_WRITE,
+			0, nil, syscall.OPEN_EXISTING, syscall.FILE_FLAG_OVERLAPPED, 0)
+		if err == nil {
+			f := os.NewFile(uintptr(h), addr)
+			defer f.Close()
+			return net.FileConn(f)
+		}
+		if err != errPipeBusy && err != syscall.ERROR_FILE_NOT_FOUND {
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout connecting to mpv named pipe %s", addr)
+		}
+		select {
+		case <-cx.Done():
+			return nil, cx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// defaultSocketPath generates a unique named pipe path for an embedded
+// mpv Server.
+func defaultSocketPath() (string, error) {
+	return fmt.Sprintf(`\\.\pipe\mpv-ipc-%d-%d`, os.Getpid(), rand.Int()), nil
+}
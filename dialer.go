@@ -0,0 +1,14 @@
+package mpv
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// dialFunc opens a connection to the mpv IPC endpoint at addr: a Unix
+// domain socket path on Unix, a named pipe path on Windows. It is set by
+// the platform-specific init() in dial_unix.go or dial_windows.go.
+type dialFunc func(cx context.Context, addr string, timeout time.Duration) (net.Conn, error)
+
+var dial dialFunc
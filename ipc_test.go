@@ -0,0 +1,168 @@
+package mpv
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeMPV is a minimal mpv IPC server for tests: it accepts a single
+// connection and acks every command with {"request_id":N,"error":"success"}.
+func fakeMPV(t *testing.T, socket string) {
+	t.Helper()
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		rd := bufio.NewReader(conn)
+		for {
+			line, err := rd.ReadBytes('\n')
+			if err != nil {
+				return
+			}
+			var req struct {
+				RequestID int `json:"request_id"`
+			}
+			if err := json.Unmarshal(line, &req); err != nil {
+				continue
+			}
+			resp, _ := json.Marshal(Response{RequestID: req.RequestID, Err: "success"})
+			resp = append(resp, '\n')
+			if _, err := conn.Write(resp); err != nil {
+				return
+			}
+		}
+	}()
+}
+
+func newTestClient(t *testing.T) *IPCClient {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "mpv.sock")
+	fakeMPV(t, socket)
+
+	c, err := NewIPCClient(context.Background(), socket)
+	if err != nil {
+		t.Fatalf("NewIPCClient: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// TestNewRequestIDsUnique exercises the concurrency newRequest is built
+// for: ExecBatch firing many requests for the same client at once. Every
+// RequestID handed out must be unique, or dispatch would misroute one
+// caller's response to another (see newRequest).
+func TestNewRequestIDsUnique(t *testing.T) {
+	c := newTestClient(t)
+
+	const n = 200
+	ids := make([]int, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i] = c.newRequest("get_property", "pause").RequestID
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[int]bool, n)
+	for _, id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate RequestID %d", id)
+		}
+		seen[id] = true
+	}
+}
+
+// TestObservePropertyConcurrentSameName exercises several goroutines racing
+// to be the first to observe the same property name. Only one observer
+// registration should win; all subscribers must still share it, and
+// unsubscribing all of them must leave no dangling entries.
+func TestObservePropertyConcurrentSameName(t *testing.T) {
+	c := newTestClient(t)
+
+	const n = 8
+	type result struct {
+		ch    <-chan PropertyChange
+		unsub func()
+	}
+	results := make([]result, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ch, unsub, err := c.ObserveProperty("pause")
+			if err != nil {
+				t.Errorf("ObserveProperty: %v", err)
+				return
+			}
+			results[i] = result{ch, unsub}
+		}(i)
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	if got := len(c.observersByName); got != 1 {
+		t.Fatalf("observersByName has %d entries, want 1", got)
+	}
+	if got := len(c.observersByID); got != 1 {
+		t.Fatalf("observersByID has %d entries, want 1", got)
+	}
+	ob := c.observersByName["pause"]
+	if got := len(ob.subs); got != n {
+		t.Fatalf("observer has %d subscribers, want %d", got, n)
+	}
+	c.mu.Unlock()
+
+	for _, r := range results {
+		if r.unsub != nil {
+			r.unsub()
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if got := len(c.observersByName); got != 0 {
+		t.Fatalf("observersByName has %d entries after unsub, want 0", got)
+	}
+	if got := len(c.observersByID); got != 0 {
+		t.Fatalf("observersByID has %d entries after unsub, want 0", got)
+	}
+}
+
+// TestWaitForPropertyAlreadySatisfied checks that Client.WaitForProperty
+// returns at once for a value that already satisfies the predicate, rather
+// than waiting for a future property-change event that will never come.
+// The predicate always holds, so the call returns at its first check,
+// before it subscribes.
+func TestWaitForPropertyAlreadySatisfied(t *testing.T) {
+	c := newTestClient(t)
+	client := NewClient(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	err := client.WaitForProperty(ctx, "pause", func(json.RawMessage) bool {
+		return true
+	})
+	if err != nil {
+		t.Fatalf("WaitForProperty: %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package mpv
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PlaylistItem is a single entry of mpv's playlist property.
+type PlaylistItem struct {
+	ID       int    `json:"id"`
+	Filename string `json:"filename"`
+	Title    string `json:"title"`
+	Current  bool   `json:"current"`
+	Playing  bool   `json:"playing"`
+}
+
+// Playlist manages the mpv playlist via Client.
+type Playlist struct {
+	c *Client
+}
+
+// Playlist returns the Playlist manager for c.
+func (c *Client) Playlist() *Playlist {
+	return &Playlist{c: c}
+}
+
+// Items returns the current playlist, in order, with the playing/current
+// entry marked.
+func (p *Playlist) Items() ([]PlaylistItem, error) {
+	res, err := p.c.Exec("get_property", "playlist")
+	if res == nil {
+		return nil, err
+	}
+	var items []PlaylistItem
+	if err := json.Unmarshal(res.Data, &items); err != nil {
+		return nil, fmt.Errorf("data %s, err %v", res.Data, err)
+	}
+	return items, nil
+}
+
+// Move moves the playlist entry at from to index to.
+func (p *Playlist) Move(from, to int) error {
+	_, err := p.c.Exec("playlist-move", from, to)
+	return err
+}
+
+// Remove removes the playlist entry at index.
+func (p *Playlist) Remove(index int) error {
+	_, err := p.c.Exec("playlist-remove", index)
+	return err
+}
+
+// RemoveCurrent removes the currently playing playlist entry.
+func (p *Playlist) RemoveCurrent() error {
+	_, err := p.c.Exec("playlist-remove", "current")
+	return err
+}
+
+// Clear removes every playlist entry except the one currently playing.
+func (p *Playlist) Clear() error {
+	_, err := p.c.Exec("playlist-clear")
+	return err
+}
+
+// Shuffle randomizes the playlist order.
+func (p *Playlist) Shuffle() error {
+	_, err := p.c.Exec("playlist-shuffle")
+	return err
+}
+
+// Unshuffle undoes the effect of the last Shuffle, restoring the
+// previous order.
+func (p *Playlist) Unshuffle() error {
+	_, err := p.c.Exec("playlist-unshuffle")
+	return err
+}
+
+// PlayIndex starts playing the playlist entry at index.
+func (p *Playlist) PlayIndex(index int) error {
+	return p.c.SetProperty("playlist-pos", index)
+}
+
+// LoadFiles appends every path to the playlist via loadfile, in mode
+// (LoadFileModeAppend or LoadFileModeAppendPlay). If the underlying
+// LLClient supports it, the loadfile commands are pipelined via
+// ExecBatch instead of paying one round trip per path.
+func (p *Playlist) LoadFiles(paths []string, mode string) error {
+	if bc, ok := p.c.LLClient.(BatchClient); ok {
+		commands := make([][]interface{}, len(paths))
+		for i, path := range paths {
+			commands[i] = []interface{}{"loadfile", path, mode}
+		}
+		_, err := bc.ExecBatch(commands...)
+		return err
+	}
+	for _, path := range paths {
+		if _, err := p.c.Exec("loadfile", path, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
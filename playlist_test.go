@@ -0,0 +1,146 @@
+package mpv
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// fakeLLClient is an in-memory LLClient (and BatchClient) for testing
+// Playlist/Queue without a real mpv process. Exec/ExecBatch record every
+// command they're given and answer from canned responses keyed by the
+// command name.
+type fakeLLClient struct {
+	responses map[string]json.RawMessage
+	execCmds  [][]interface{}
+	batches   [][][]interface{}
+}
+
+func newFakeLLClient() *fakeLLClient {
+	return &fakeLLClient{responses: make(map[string]json.RawMessage)}
+}
+
+func (f *fakeLLClient) Exec(command ...interface{}) (*Response, error) {
+	f.execCmds = append(f.execCmds, command)
+	name, _ := command[0].(string)
+	return &Response{Data: f.responses[name]}, nil
+}
+
+func (f *fakeLLClient) Close() error { return nil }
+
+func (f *fakeLLClient) ExecContext(ctx context.Context, command ...interface{}) (*Response, error) {
+	return f.Exec(command...)
+}
+
+func (f *fakeLLClient) ExecBatch(commands ...[]interface{}) ([]*Response, error) {
+	f.batches = append(f.batches, commands)
+	res := make([]*Response, len(commands))
+	for i, cmd := range commands {
+		r, _ := f.Exec(cmd...)
+		res[i] = r
+	}
+	// Undo the per-command bookkeeping Exec just did for these commands so
+	// execCmds only reflects commands sent outside of a batch.
+	f.execCmds = f.execCmds[:len(f.execCmds)-len(commands)]
+	return res, nil
+}
+
+func (f *fakeLLClient) ExecBatchContext(ctx context.Context, commands ...[]interface{}) ([]*Response, error) {
+	return f.ExecBatch(commands...)
+}
+
+func TestPlaylistItems(t *testing.T) {
+	f := newFakeLLClient()
+	f.responses["get_property"] = json.RawMessage(`[{"id":0,"filename":"a.mp4","current":true},{"id":1,"filename":"b.mp4"}]`)
+	c := NewClient(f)
+
+	items, err := c.Playlist().Items()
+	if err != nil {
+		t.Fatalf("Items: %v", err)
+	}
+	want := []PlaylistItem{
+		{ID: 0, Filename: "a.mp4", Current: true},
+		{ID: 1, Filename: "b.mp4"},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Fatalf("Items = %+v, want %+v", items, want)
+	}
+}
+
+func TestPlaylistLoadFilesUsesExecBatch(t *testing.T) {
+	f := newFakeLLClient()
+	c := NewClient(f)
+
+	paths := []string{"a.mp4", "b.mp4", "c.mp4"}
+	if err := c.Playlist().LoadFiles(paths, LoadFileModeAppend); err != nil {
+		t.Fatalf("LoadFiles: %v", err)
+	}
+
+	if len(f.batches) != 1 {
+		t.Fatalf("got %d ExecBatch calls, want 1", len(f.batches))
+	}
+	if len(f.execCmds) != 0 {
+		t.Fatalf("LoadFiles issued %d plain Exec calls, want 0 (should all be batched)", len(f.execCmds))
+	}
+	got := f.batches[0]
+	if len(got) != len(paths) {
+		t.Fatalf("batch has %d commands, want %d", len(got), len(paths))
+	}
+	for i, path := range paths {
+		want := []interface{}{"loadfile", path, LoadFileModeAppend}
+		if !reflect.DeepEqual(got[i], want) {
+			t.Errorf("batch[%d] = %v, want %v", i, got[i], want)
+		}
+	}
+}
+
+// execOnlyClient implements only LLClient, not BatchClient (unlike
+// fakeLLClient, its methods can't be promoted into satisfying it), to
+// exercise LoadFiles' sequential fallback.
+type execOnlyClient struct {
+	execCmds [][]interface{}
+}
+
+func (f *execOnlyClient) Exec(command ...interface{}) (*Response, error) {
+	f.execCmds = append(f.execCmds, command)
+	return &Response{}, nil
+}
+
+func (f *execOnlyClient) Close() error { return nil }
+
+func TestPlaylistLoadFilesFallsBackWithoutBatchClient(t *testing.T) {
+	f := &execOnlyClient{}
+	c := NewClient(f)
+
+	paths := []string{"a.mp4", "b.mp4"}
+	if err := c.Playlist().LoadFiles(paths, LoadFileModeAppend); err != nil {
+		t.Fatalf("LoadFiles: %v", err)
+	}
+	if len(f.execCmds) != len(paths) {
+		t.Fatalf("got %d Exec calls, want %d", len(f.execCmds), len(paths))
+	}
+}
+
+func TestQueueAddAll(t *testing.T) {
+	f := newFakeLLClient()
+	c := NewClient(f)
+	q := NewQueue(c)
+
+	paths := []string{"a.mp4", "b.mp4", "c.mp4"}
+	if err := q.AddAll(paths); err != nil {
+		t.Fatalf("AddAll: %v", err)
+	}
+
+	if len(f.execCmds) != 1 {
+		t.Fatalf("got %d plain Exec calls, want 1 (the first loadfile)", len(f.execCmds))
+	}
+	want := []interface{}{"loadfile", "a.mp4", LoadFileModeAppendPlay}
+	if !reflect.DeepEqual(f.execCmds[0], want) {
+		t.Fatalf("first Exec = %v, want %v", f.execCmds[0], want)
+	}
+
+	if len(f.batches) != 1 || len(f.batches[0]) != 2 {
+		t.Fatalf("ExecBatch calls = %v, want a single batch of 2 commands", f.batches)
+	}
+}
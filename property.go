@@ -0,0 +1,40 @@
+package mpv
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Property pairs an mpv property name with the Go type its value decodes
+// to, so callers of Get/Set get compile-time type safety instead of
+// juggling raw JSON or bare strings. The catalog of well-known mpv
+// properties lives in the props subpackage, e.g. props.Pause.
+type Property[T any] struct {
+	Name string
+}
+
+// Get reads the property via c and decodes it into T.
+func (p Property[T]) Get(c *Client) (T, error) {
+	return GetPropertyAs[T](c, p.Name)
+}
+
+// Set sets the property via c.
+func (p Property[T]) Set(c *Client, v T) error {
+	return c.SetProperty(p.Name, v)
+}
+
+// GetPropertyAs reads property name via c and JSON-decodes its value
+// into T. Prefer a typed Property from the props package over calling
+// this directly with a bare string, e.g. props.Pause.Get(c).
+func GetPropertyAs[T any](c *Client, name string) (T, error) {
+	var zero T
+	raw, err := c.GetPropertyRaw(name)
+	if err != nil {
+		return zero, err
+	}
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return zero, fmt.Errorf("data %s, err %v", raw, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,31 @@
+// Package props catalogs well-known mpv IPC properties as typed
+// mpv.Property descriptors, so callers get compile-time type safety
+// instead of juggling raw JSON or property name strings.
+//
+//	paused, err := props.Pause.Get(c)
+//	err = props.Volume.Set(c, 80)
+package props
+
+import "github.com/themihai/mpv"
+
+var (
+	Pause      = mpv.Property[bool]{Name: "pause"}
+	Mute       = mpv.Property[bool]{Name: "mute"}
+	Fullscreen = mpv.Property[bool]{Name: "fullscreen"}
+	Idle       = mpv.Property[bool]{Name: "idle"}
+
+	Volume     = mpv.Property[float64]{Name: "volume"}
+	Speed      = mpv.Property[float64]{Name: "speed"}
+	Duration   = mpv.Property[float64]{Name: "duration"}
+	TimePos    = mpv.Property[float64]{Name: "time-pos"}
+	PercentPos = mpv.Property[float64]{Name: "percent-pos"}
+	AbLoopA    = mpv.Property[float64]{Name: "ab-loop-a"}
+	AbLoopB    = mpv.Property[float64]{Name: "ab-loop-b"}
+
+	Chapter = mpv.Property[int]{Name: "chapter"}
+
+	Filename = mpv.Property[string]{Name: "filename"}
+	Path     = mpv.Property[string]{Name: "path"}
+
+	TrackList = mpv.Property[[]mpv.Track]{Name: "track-list"}
+)
@@ -0,0 +1,130 @@
+package mpv
+
+// QueueLoopMode controls what happens once playback reaches the end of
+// the queue.
+type QueueLoopMode string
+
+const (
+	QueueLoopOff   QueueLoopMode = "no"    // stop when the playlist ends
+	QueueLoopTrack QueueLoopMode = "track" // repeat the current track
+	QueueLoopAll   QueueLoopMode = "all"   // repeat the whole playlist
+)
+
+// Queue is a higher-level playback queue built on top of Playlist. It
+// layers a loop mode and local play history over mpv's playlist, the way
+// a music bot or jukebox would.
+type Queue struct {
+	c  *Client
+	pl *Playlist
+
+	history []string
+	mode    QueueLoopMode
+}
+
+// NewQueue creates a Queue for c.
+func NewQueue(c *Client) *Queue {
+	return &Queue{c: c, pl: c.Playlist()}
+}
+
+// Playlist returns the underlying Playlist.
+func (q *Queue) Playlist() *Playlist {
+	return q.pl
+}
+
+// Add appends path to the queue, starting playback if nothing is playing.
+func (q *Queue) Add(path string) error {
+	return q.c.Loadfile(path, LoadFileModeAppendPlay)
+}
+
+// AddAll appends every path to the queue, starting playback if nothing
+// is playing.
+func (q *Queue) AddAll(paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	if err := q.Add(paths[0]); err != nil {
+		return err
+	}
+	return q.pl.LoadFiles(paths[1:], LoadFileModeAppend)
+}
+
+// Next records the currently playing item in History and advances to the
+// next queue entry.
+func (q *Queue) Next() error {
+	q.recordHistory()
+	return q.c.PlaylistNext()
+}
+
+// Previous jumps back to the queue entry before the current one.
+func (q *Queue) Previous() error {
+	return q.c.PlaylistPrevious()
+}
+
+// History returns the filenames Next has advanced past, oldest first.
+func (q *Queue) History() []string {
+	return append([]string(nil), q.history...)
+}
+
+func (q *Queue) recordHistory() {
+	items, err := q.pl.Items()
+	if err != nil {
+		return
+	}
+	for _, it := range items {
+		if it.Current {
+			q.history = append(q.history, it.Filename)
+			return
+		}
+	}
+}
+
+// Upcoming returns the filenames still queued after the current entry.
+func (q *Queue) Upcoming() ([]string, error) {
+	items, err := q.pl.Items()
+	if err != nil {
+		return nil, err
+	}
+	var upcoming []string
+	found := false
+	for _, it := range items {
+		if found {
+			upcoming = append(upcoming, it.Filename)
+			continue
+		}
+		found = it.Current
+	}
+	return upcoming, nil
+}
+
+// SetShuffle shuffles or restores the queue's order.
+func (q *Queue) SetShuffle(on bool) error {
+	if on {
+		return q.pl.Shuffle()
+	}
+	return q.pl.Unshuffle()
+}
+
+// LoopMode returns the loop mode last set via SetLoopMode.
+func (q *Queue) LoopMode() QueueLoopMode {
+	return q.mode
+}
+
+// SetLoopMode sets how the queue behaves once playback reaches its end,
+// translating to mpv's loop-file/loop-playlist properties.
+func (q *Queue) SetLoopMode(mode QueueLoopMode) error {
+	loopFile, loopPlaylist := "no", "no"
+	switch mode {
+	case QueueLoopTrack:
+		loopFile = "inf"
+	case QueueLoopAll:
+		loopPlaylist = "inf"
+	}
+	if err := q.c.SetProperty("loop-file", loopFile); err != nil {
+		return err
+	}
+	if err := q.c.SetProperty("loop-playlist", loopPlaylist); err != nil {
+		return err
+	}
+	q.mode = mode
+	return nil
+}
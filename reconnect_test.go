@@ -0,0 +1,161 @@
+package mpv
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeMPVServer is a fakeMPV that accepts connections in a loop instead of
+// just one, so tests can force a disconnect and observe the client
+// reconnect to a fresh connection.
+type fakeMPVServer struct {
+	ln net.Listener
+}
+
+func newFakeMPVServer(t *testing.T, socket string) *fakeMPVServer {
+	t.Helper()
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeMPVServer{ln: ln}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeMPVServer) serve(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		line, err := rd.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		var req struct {
+			RequestID int `json:"request_id"`
+		}
+		if err := json.Unmarshal(line, &req); err != nil {
+			continue
+		}
+		resp, _ := json.Marshal(Response{RequestID: req.RequestID, Err: "success"})
+		resp = append(resp, '\n')
+		if _, err := conn.Write(resp); err != nil {
+			return
+		}
+	}
+}
+
+// TestReconnectAfterDisconnect forces the live connection closed from the
+// server side and verifies the supervisor reconnects and the client is
+// usable again, exercising the exact reconnect path connect/supervise
+// implement.
+func TestReconnectAfterDisconnect(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "mpv.sock")
+	newFakeMPVServer(t, socket)
+
+	disconnected := make(chan struct{}, 1)
+	reconnected := make(chan struct{}, 1)
+	cfg := IPCClientConfig{
+		ReconnectBackoff: 10 * time.Millisecond,
+		MaxBackoff:       50 * time.Millisecond,
+		OnDisconnect:     func() { disconnected <- struct{}{} },
+		OnReconnect:      func() { reconnected <- struct{}{} },
+	}
+
+	c, err := NewIPCClient(context.Background(), socket, cfg)
+	if err != nil {
+		t.Fatalf("NewIPCClient: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	if _, err := c.Exec("get_property", "pause"); err != nil {
+		t.Fatalf("Exec before disconnect: %v", err)
+	}
+
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	conn.Close()
+
+	select {
+	case <-disconnected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnDisconnect was never called")
+	}
+	select {
+	case <-reconnected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnReconnect was never called")
+	}
+
+	if _, err := c.Exec("get_property", "pause"); err != nil {
+		t.Fatalf("Exec after reconnect: %v", err)
+	}
+}
+
+// TestExecReturnsErrDisconnectedOnDrop exercises the chunk0-2 fix directly:
+// a non-idempotent request in flight when the connection drops must fail
+// with ErrDisconnected as the real Go error, not a silently-successful
+// Response whose Err field the caller never looks at.
+func TestExecReturnsErrDisconnectedOnDrop(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "mpv.sock")
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		accepted <- conn
+		// Never respond: the request stays pending until the connection
+		// is dropped from under it.
+		bufio.NewReader(conn).ReadBytes('\n')
+	}()
+
+	cfg := IPCClientConfig{ReconnectBackoff: time.Minute}
+	ipc, err := NewIPCClient(context.Background(), socket, cfg)
+	if err != nil {
+		t.Fatalf("NewIPCClient: %v", err)
+	}
+	t.Cleanup(func() { ipc.Close() })
+	client := NewClient(ipc)
+
+	conn := <-accepted
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- client.SetProperty("pause", true)
+	}()
+
+	// Give SetProperty time to land in reqMap before we pull the rug.
+	time.Sleep(50 * time.Millisecond)
+	conn.Close()
+
+	select {
+	case err := <-errCh:
+		if err != ErrDisconnected {
+			t.Fatalf("got err %v, want ErrDisconnected", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("SetProperty never returned after the connection dropped")
+	}
+}
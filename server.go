@@ -0,0 +1,219 @@
+package mpv
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// ServerConfig configures an embedded mpv child process started by
+// NewServer.
+type ServerConfig struct {
+	// MPVPath is the path to the mpv binary. Defaults to "mpv", resolved
+	// via PATH.
+	MPVPath string
+	// ExtraArgs are appended to the mpv invocation, after the flags
+	// Server itself sets (--idle, --input-ipc-server, --no-terminal).
+	ExtraArgs []string
+	// SocketPath is the IPC endpoint mpv listens on: a Unix domain
+	// socket path, or a Windows named pipe path. A unique path is
+	// generated if left empty.
+	SocketPath string
+	// Stderr, if set, receives mpv's stderr output.
+	Stderr io.Writer
+	// StartTimeout bounds how long to wait for mpv's IPC socket to come
+	// up. Defaults to 5s.
+	StartTimeout time.Duration
+	// AutoRestart relaunches mpv, and reconnects the Client, if the
+	// process exits unexpectedly (i.e. not via Server.Quit).
+	AutoRestart bool
+	// IPCClientConfig configures the reconnect supervisor of the Client
+	// returned by NewServer.
+	IPCClientConfig IPCClientConfig
+}
+
+// Server manages an mpv child process running in --idle mode, exposing
+// its IPC socket as a Client.
+type Server struct {
+	cfg    ServerConfig
+	cancel context.CancelFunc
+	// stopped is closed exactly once, by run, when the managed process
+	// has permanently stopped (Quit was called, or it exited and
+	// AutoRestart is off). Quit blocks on it instead of reading exited
+	// directly, so run is always the sole reader of exited.
+	stopped chan struct{}
+
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	client  *Client
+	exited  chan error // receives the running process's Wait() error exactly once
+	lastErr error
+	quit    bool
+}
+
+// NewServer launches mpv --idle with an IPC socket at cfg.SocketPath (or
+// a generated path) and returns a Server wrapping the connected Client.
+func NewServer(cx context.Context, cfg ServerConfig) (*Server, error) {
+	if cfg.MPVPath == "" {
+		cfg.MPVPath = "mpv"
+	}
+	if cfg.StartTimeout <= 0 {
+		cfg.StartTimeout = 5 * time.Second
+	}
+	if cfg.SocketPath == "" {
+		path, err := defaultSocketPath()
+		if err != nil {
+			return nil, err
+		}
+		cfg.SocketPath = path
+	}
+
+	ctx, cancel := context.WithCancel(cx)
+	s := &Server{cfg: cfg, cancel: cancel, stopped: make(chan struct{})}
+	if err := s.launch(ctx); err != nil {
+		cancel()
+		return nil, err
+	}
+	go s.run(ctx)
+	return s, nil
+}
+
+func (s *Server) args() []string {
+	argv := []string{"--idle", "--input-ipc-server=" + s.cfg.SocketPath, "--no-terminal"}
+	return append(argv, s.cfg.ExtraArgs...)
+}
+
+// launch starts the mpv process and blocks until its IPC socket accepts
+// connections, then wires up the Client.
+func (s *Server) launch(cx context.Context) error {
+	cmd := exec.CommandContext(cx, s.cfg.MPVPath, s.args()...)
+	cmd.Stderr = s.cfg.Stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	llc, err := waitForIPC(cx, s.cfg.SocketPath, s.cfg.StartTimeout, s.cfg.IPCClientConfig)
+	if err != nil {
+		cmd.Process.Kill()
+		<-exited
+		return err
+	}
+
+	s.mu.Lock()
+	oldClient := s.client
+	s.cmd = cmd
+	s.client = NewClient(llc)
+	s.exited = exited
+	s.mu.Unlock()
+	if oldClient != nil {
+		// launch is also called by run on an AutoRestart relaunch, when
+		// s.client still holds the previous (now-dead) mpv instance's
+		// client; closing it here stops its reconnect-supervisor
+		// goroutine and live IPC connection from leaking every cycle.
+		oldClient.Close()
+	}
+	return nil
+}
+
+// waitForIPC retries NewIPCClient, passing cfg through, until mpv's socket
+// is ready, timeout has elapsed, or cx is done.
+func waitForIPC(cx context.Context, socket string, timeout time.Duration, cfg IPCClientConfig) (*IPCClient, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		c, err := NewIPCClient(cx, socket, cfg)
+		if err == nil {
+			return c, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, err
+		}
+		select {
+		case <-cx.Done():
+			return nil, cx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// Client returns the Client connected to the managed mpv instance. It is
+// replaced with a freshly connected Client whenever AutoRestart relaunches
+// mpv.
+func (s *Server) Client() *Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client
+}
+
+// run is the sole reader of s.exited across the Server's lifetime: it
+// relaunches mpv whenever it exits unexpectedly and AutoRestart is set,
+// and closes s.stopped once the process has stopped for good, whether
+// because Quit was called, AutoRestart is off, or cx was cancelled. Quit
+// relies on that close rather than racing run for s.exited directly.
+func (s *Server) run(cx context.Context) {
+	defer close(s.stopped)
+	for {
+		s.mu.Lock()
+		exited := s.exited
+		s.mu.Unlock()
+		if exited == nil {
+			return
+		}
+
+		var err error
+		select {
+		case err = <-exited:
+		case <-cx.Done():
+			return
+		}
+
+		s.mu.Lock()
+		s.lastErr = err
+		quit := s.quit
+		s.mu.Unlock()
+		if quit || !s.cfg.AutoRestart {
+			return
+		}
+
+		select {
+		case <-cx.Done():
+			return
+		default:
+		}
+		if err := s.launch(cx); err != nil {
+			return
+		}
+	}
+}
+
+// Quit asks mpv to exit gracefully via the quit command and waits for the
+// process to stop, forcibly killing it if cx is done first. After Quit,
+// AutoRestart no longer relaunches the process.
+func (s *Server) Quit(cx context.Context) error {
+	s.mu.Lock()
+	s.quit = true
+	client := s.client
+	cmd := s.cmd
+	s.mu.Unlock()
+
+	if client != nil {
+		client.Quit(0)
+	}
+
+	select {
+	case <-s.stopped:
+		s.cancel()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.lastErr
+	case <-cx.Done():
+		if cmd != nil && cmd.Process != nil {
+			cmd.Process.Kill()
+		}
+		s.cancel()
+		return cx.Err()
+	}
+}
@@ -0,0 +1,169 @@
+package mpv
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeMPVProcessFlag marks an argv passed to a child process spawned by
+// Server as the one that should run runFakeMPVProcess instead of the test
+// suite; see TestMain.
+const fakeMPVProcessFlag = "-mpv-fake-process"
+
+func TestMain(m *testing.M) {
+	for _, arg := range os.Args[1:] {
+		if arg == fakeMPVProcessFlag {
+			runFakeMPVProcess()
+			return
+		}
+	}
+	os.Exit(m.Run())
+}
+
+// runFakeMPVProcess stands in for the mpv binary in Server tests: it
+// parses --input-ipc-server out of its own argv, serves just enough of
+// the IPC protocol to satisfy NewIPCClient/Exec, and exits on a "quit"
+// command (graceful, like real mpv) or the test-only "crash" command
+// (to simulate an unexpected exit for AutoRestart).
+func runFakeMPVProcess() {
+	var socket string
+	for _, arg := range os.Args[1:] {
+		if s, ok := strings.CutPrefix(arg, "--input-ipc-server="); ok {
+			socket = s
+		}
+	}
+	if socket == "" {
+		os.Exit(2)
+	}
+	// A previous instance of this fake process may have os.Exit'd (to
+	// simulate a crash) without its deferred ln.Close() running, leaving
+	// the socket file behind.
+	os.Remove(socket)
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		os.Exit(2)
+	}
+	defer ln.Close()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		if serveFakeMPVConn(conn) {
+			return
+		}
+	}
+}
+
+// serveFakeMPVConn serves one connection's commands until reading from it
+// fails, then returns false. A quit or crash command ends the whole
+// process via os.Exit instead, so it never returns true.
+func serveFakeMPVConn(conn net.Conn) (exit bool) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		line, err := rd.ReadBytes('\n')
+		if err != nil {
+			return false
+		}
+		var req struct {
+			Command   []interface{} `json:"command"`
+			RequestID int           `json:"request_id"`
+		}
+		if err := json.Unmarshal(line, &req); err != nil {
+			continue
+		}
+		resp, _ := json.Marshal(Response{RequestID: req.RequestID, Err: "success"})
+		conn.Write(append(resp, '\n'))
+
+		if len(req.Command) == 0 {
+			continue
+		}
+		name, _ := req.Command[0].(string)
+		switch name {
+		case "quit":
+			os.Exit(0)
+		case "crash":
+			os.Exit(1)
+		}
+	}
+}
+
+func fakeServerConfig(t *testing.T) ServerConfig {
+	t.Helper()
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("os.Executable: %v", err)
+	}
+	return ServerConfig{
+		MPVPath:      exe,
+		ExtraArgs:    []string{fakeMPVProcessFlag},
+		StartTimeout: 5 * time.Second,
+	}
+}
+
+// TestServerQuit exercises the chunk0-3 fix: Quit must return once the
+// fake process has exited after a graceful quit command, rather than
+// racing the reconnect loop for the process's exited channel.
+func TestServerQuit(t *testing.T) {
+	cfg := fakeServerConfig(t)
+	s, err := NewServer(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	if _, err := s.Client().Exec("get_property", "pause"); err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Quit(ctx); err != nil {
+		t.Fatalf("Quit: %v", err)
+	}
+}
+
+// TestServerAutoRestart forces the fake process to exit unexpectedly and
+// verifies Server relaunches it and reconnects a usable Client, then
+// confirms a subsequent Quit still stops it cleanly.
+func TestServerAutoRestart(t *testing.T) {
+	cfg := fakeServerConfig(t)
+	cfg.AutoRestart = true
+	s, err := NewServer(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	firstClient := s.Client()
+	if _, err := firstClient.Exec("crash"); err != nil {
+		t.Fatalf("Exec(crash): %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	var restarted *Client
+	for time.Now().Before(deadline) {
+		if c := s.Client(); c != firstClient {
+			if _, err := c.Exec("get_property", "pause"); err == nil {
+				restarted = c
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if restarted == nil {
+		t.Fatal("Server never relaunched a usable Client after the crash")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Quit(ctx); err != nil {
+		t.Fatalf("Quit after restart: %v", err)
+	}
+}